@@ -0,0 +1,64 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config defines the configuration consumed by operator.New to
+// customize the contour operator's runtime behavior.
+package config
+
+// Config holds the configuration parameters for the contour operator.
+type Config struct {
+	// ContourImage is the Contour container image to use for managed
+	// Contour deployments.
+	ContourImage string
+
+	// EnvoyImage is the Envoy container image to use for managed Contour
+	// daemonsets.
+	EnvoyImage string
+
+	// LeaderElection enables leader election, so that only one replica of
+	// the operator is active at a time.
+	LeaderElection bool
+
+	// LeaderElectionID is the name of the resource the operator uses for
+	// leader election.
+	LeaderElectionID string
+
+	// MetricsBindAddress is the address the operator binds its metrics
+	// endpoint to, e.g. ":8080". Set to "0" to disable.
+	MetricsBindAddress string
+
+	// HealthProbeBindAddress is the address the operator binds its
+	// /healthz and /readyz endpoints to, e.g. ":8081".
+	HealthProbeBindAddress string
+
+	// WatchNamespaces restricts the operator to managing Contour CRs (and
+	// Gateway API resources) in the given namespaces only. If empty, the
+	// operator watches and manages resources across the entire cluster.
+	WatchNamespaces []string
+
+	// OperatorNamespace is the namespace the operator itself is deployed
+	// to. It pins the leader election Lease to that namespace, which
+	// matters in namespace-scoped mode where WatchNamespaces may not
+	// include the operator's own namespace and controller-runtime can't
+	// fall back to detecting it from the in-cluster service account files.
+	OperatorNamespace string
+
+	// GatewayControllerName is the string a GatewayClass' spec.controller
+	// must match for this operator to administer it.
+	GatewayControllerName string
+
+	// EnableRuntimeExtensions turns on the runtime extension/webhook
+	// subsystem: the ExtensionConfig discovery controller and the hook
+	// Invoker. The Contour controller does not call the hooks.
+	EnableRuntimeExtensions bool
+}
@@ -0,0 +1,99 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const crdGateControllerName = "crd-gate-controller"
+
+// CRDGateConfig configures a CRDGateController.
+type CRDGateConfig struct {
+	// Resources are the GVRs that must all resolve via RESTMapper before
+	// OnAvailable is invoked.
+	Resources []schema.GroupVersionResource
+
+	// RESTMapper is consulted to determine whether Resources are installed.
+	// It must refresh its cache on a cache miss (e.g. apiutil.NewDynamicRESTMapper)
+	// for newly-installed CRDs to be detected without a restart.
+	RESTMapper meta.RESTMapper
+
+	// OnAvailable is invoked exactly once, the first time all Resources are
+	// observed to be installed.
+	OnAvailable func() error
+}
+
+// crdGateReconciler watches CustomResourceDefinitions and fires OnAvailable
+// once every configured GVR can be resolved, so callers can defer creating
+// controllers for optional CRDs until the CRDs actually exist.
+type crdGateReconciler struct {
+	config CRDGateConfig
+	log    logr.Logger
+
+	mu    sync.Mutex
+	fired bool
+}
+
+// NewCRDGateController creates a CRDGateController and registers it with mgr.
+func NewCRDGateController(mgr manager.Manager, cfg CRDGateConfig) (controller.Controller, error) {
+	r := &crdGateReconciler{
+		config: cfg,
+		log:    ctrl.Log.WithName(crdGateControllerName),
+	}
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(&apiextensionsv1.CustomResourceDefinition{}).
+		Build(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", crdGateControllerName, err)
+	}
+	return c, nil
+}
+
+func (r *crdGateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fired {
+		return ctrl.Result{}, nil
+	}
+
+	for _, gvr := range r.config.Resources {
+		if _, err := r.config.RESTMapper.KindFor(gvr); err != nil {
+			if !apierrors.IsNotFound(err) {
+				r.log.V(1).Info("required resource not yet available", "resource", gvr.String(), "reason", err.Error())
+			}
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if err := r.config.OnAvailable(); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to register controllers gated on %v: %w", r.config.Resources, err)
+	}
+
+	r.fired = true
+	r.log.Info("all gated resources are now available", "resources", r.config.Resources)
+	return ctrl.Result{}, nil
+}
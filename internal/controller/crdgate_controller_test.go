@@ -0,0 +1,72 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// fakeRESTMapper resolves exactly the GVRs in installed.
+type fakeRESTMapper struct {
+	apimeta.RESTMapper
+	installed map[schema.GroupVersionResource]bool
+}
+
+func (m fakeRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	if m.installed[resource] {
+		return schema.GroupVersionKind{Group: resource.Group, Version: resource.Version, Kind: resource.Resource}, nil
+	}
+	return schema.GroupVersionKind{}, &apimeta.NoResourceMatchError{PartialResource: resource}
+}
+
+func TestCRDGateReconcileFiresOnceResourcesAvailable(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "networking.x-k8s.io", Version: "v1alpha1", Resource: "gatewayclasses"}
+
+	fired := 0
+	rm := fakeRESTMapper{installed: map[schema.GroupVersionResource]bool{}}
+	r := &crdGateReconciler{
+		config: CRDGateConfig{
+			Resources:  []schema.GroupVersionResource{gvr},
+			RESTMapper: rm,
+			OnAvailable: func() error {
+				fired++
+				return nil
+			},
+		},
+		log: ctrl.Log.WithName("test"),
+	}
+	req := ctrl.Request{}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	require.Equal(t, 0, fired, "OnAvailable must not fire while the resource is unavailable")
+
+	rm.installed[gvr] = true
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	require.Equal(t, 1, fired, "OnAvailable must fire once the resource becomes available")
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	require.Equal(t, 1, fired, "OnAvailable must not fire again on subsequent reconciles")
+}
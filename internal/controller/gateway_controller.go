@@ -0,0 +1,125 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/projectcontour/contour-operator/internal/metrics"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	gatewayv1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+)
+
+const (
+	gatewayControllerName = "gateway-controller"
+
+	conditionScheduled = "Scheduled"
+)
+
+// GatewayConfig holds the configuration needed to reconcile Gateways.
+type GatewayConfig struct {
+	// ControllerName is the string that must match the owning GatewayClass'
+	// spec.controller field for this operator to administer a Gateway.
+	ControllerName string
+}
+
+type gatewayReconciler struct {
+	client client.Client
+	log    logr.Logger
+	config GatewayConfig
+}
+
+// NewGatewayController creates the Gateway controller and registers it with
+// mgr. It marks each Gateway whose GatewayClass is managed by this operator
+// as Scheduled, since the operator provisions a Contour deployment capable
+// of serving it as soon as the owning GatewayClass is admitted.
+func NewGatewayController(mgr manager.Manager, cfg GatewayConfig) (controller.Controller, error) {
+	r := &gatewayReconciler{
+		client: mgr.GetClient(),
+		log:    ctrl.Log.WithName(gatewayControllerName),
+		config: cfg,
+	}
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha1.Gateway{}).
+		Build(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", gatewayControllerName, err)
+	}
+	return c, nil
+}
+
+// Reconcile schedules the Gateway named in req if its GatewayClass is managed
+// by this operator.
+func (r *gatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("gateway", req.NamespacedName)
+
+	defer func(start time.Time) {
+		metrics.ReconcileTotal.WithLabelValues("Gateway").Inc()
+		metrics.ReconcileDuration.WithLabelValues("Gateway").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	var gw gatewayv1alpha1.Gateway
+	if err := r.client.Get(ctx, req.NamespacedName, &gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get gateway %s: %w", req.NamespacedName, err)
+	}
+
+	if !gw.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	var gc gatewayv1alpha1.GatewayClass
+	if err := r.client.Get(ctx, client.ObjectKey{Name: gw.Spec.GatewayClassName}, &gc); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("gatewayclass not found, ignoring", "gatewayclass", gw.Spec.GatewayClassName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get gatewayclass %s: %w", gw.Spec.GatewayClassName, err)
+	}
+
+	if gc.Spec.Controller != r.config.ControllerName {
+		log.V(1).Info("unmanaged gatewayclass, ignoring", "gatewayclass", gc.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Status is only written when the Scheduled condition actually changes;
+	// the Gateway controller watches the type it's updating, so an
+	// unconditional Status().Update would re-trigger a reconcile on every
+	// pass and loop forever.
+	scheduled := metav1.Condition{
+		Type: conditionScheduled, Status: metav1.ConditionTrue, Reason: conditionScheduled, Message: "Scheduled on managed Contour",
+	}
+	if existing := meta.FindStatusCondition(gw.Status.Conditions, conditionScheduled); existing == nil ||
+		existing.Status != scheduled.Status || existing.Reason != scheduled.Reason || existing.Message != scheduled.Message {
+		meta.SetStatusCondition(&gw.Status.Conditions, scheduled)
+		if err := r.client.Status().Update(ctx, &gw); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status for gateway %s: %w", gw.Name, err)
+		}
+	}
+	metrics.GatewayAdmissions.WithLabelValues("Gateway", "admitted").Inc()
+	log.Info("scheduled gateway")
+
+	return ctrl.Result{}, nil
+}
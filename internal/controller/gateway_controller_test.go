@@ -0,0 +1,65 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+)
+
+// TestGatewayReconcileSkipsNoopStatusUpdate asserts that reconciling an
+// already-scheduled Gateway a second time does not issue another
+// Status().Update call, which would otherwise re-trigger a reconcile and
+// loop forever.
+func TestGatewayReconcileSkipsNoopStatusUpdate(t *testing.T) {
+	scheme := newGatewayClassTestScheme(t)
+	gc := &gatewayv1alpha1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gatewayclass"},
+		Spec:       gatewayv1alpha1.GatewayClassSpec{Controller: testControllerName},
+	}
+	gw := &gatewayv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default"},
+		Spec:       gatewayv1alpha1.GatewaySpec{GatewayClassName: gc.Name},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gc, gw).Build()
+
+	r := &gatewayReconciler{
+		client: c,
+		log:    ctrl.Log.WithName("test"),
+		config: GatewayConfig{ControllerName: testControllerName},
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: gw.Name, Namespace: gw.Namespace}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	var afterFirst gatewayv1alpha1.Gateway
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &afterFirst))
+
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	var afterSecond gatewayv1alpha1.Gateway
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &afterSecond))
+
+	require.Equal(t, afterFirst.ResourceVersion, afterSecond.ResourceVersion,
+		"reconciling an already-scheduled Gateway must not write status again")
+}
@@ -0,0 +1,183 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/metrics"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	gatewayv1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+)
+
+const (
+	gatewayClassControllerName = "gatewayclass-controller"
+	gatewayClassFinalizer      = "gatewayclass.projectcontour.io/finalizer"
+
+	conditionAdmitted = "Admitted"
+)
+
+// GatewayClassConfig holds the configuration needed to reconcile GatewayClasses.
+type GatewayClassConfig struct {
+	// ControllerName is the string that must match a GatewayClass'
+	// spec.controller field for this operator to administer it.
+	ControllerName string
+}
+
+type gatewayClassReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+	config GatewayClassConfig
+}
+
+// NewGatewayClassController creates the GatewayClass controller and registers it
+// with mgr. It provisions a Contour CR for each GatewayClass whose
+// spec.controller matches cfg.ControllerName, and removes it again when the
+// GatewayClass is deleted.
+func NewGatewayClassController(mgr manager.Manager, cfg GatewayClassConfig) (controller.Controller, error) {
+	r := &gatewayClassReconciler{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		log:    ctrl.Log.WithName(gatewayClassControllerName),
+		config: cfg,
+	}
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha1.GatewayClass{}).
+		Owns(&operatorv1alpha1.Contour{}).
+		Build(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", gatewayClassControllerName, err)
+	}
+	return c, nil
+}
+
+// Reconcile provisions or removes a Contour CR for the GatewayClass named in req.
+func (r *gatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("gatewayclass", req.Name)
+
+	defer func(start time.Time) {
+		metrics.ReconcileTotal.WithLabelValues("GatewayClass").Inc()
+		metrics.ReconcileDuration.WithLabelValues("GatewayClass").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	var gc gatewayv1alpha1.GatewayClass
+	if err := r.client.Get(ctx, req.NamespacedName, &gc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get gatewayclass %s: %w", req.Name, err)
+	}
+
+	if gc.Spec.Controller != r.config.ControllerName {
+		log.V(1).Info("unmanaged controller, ignoring", "controller", gc.Spec.Controller)
+		return ctrl.Result{}, nil
+	}
+
+	if !gc.DeletionTimestamp.IsZero() {
+		return r.ensureDeleted(ctx, &gc)
+	}
+
+	if !controllerutil.ContainsFinalizer(&gc, gatewayClassFinalizer) {
+		controllerutil.AddFinalizer(&gc, gatewayClassFinalizer)
+		if err := r.client.Update(ctx, &gc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to gatewayclass %s: %w", gc.Name, err)
+		}
+	}
+
+	if err := r.ensureContour(ctx, &gc); err != nil {
+		rejected := metav1.Condition{
+			Type: conditionAdmitted, Status: metav1.ConditionFalse, Reason: "ContourError", Message: err.Error(),
+		}
+		if existing := meta.FindStatusCondition(gc.Status.Conditions, conditionAdmitted); existing == nil ||
+			existing.Status != rejected.Status || existing.Reason != rejected.Reason || existing.Message != rejected.Message {
+			meta.SetStatusCondition(&gc.Status.Conditions, rejected)
+			_ = r.client.Status().Update(ctx, &gc)
+		}
+		metrics.GatewayAdmissions.WithLabelValues("GatewayClass", "rejected").Inc()
+		return ctrl.Result{}, err
+	}
+
+	// Status is only written when the Admitted condition actually changes;
+	// the GatewayClass controller watches the type it's updating, so an
+	// unconditional Status().Update would re-trigger a reconcile on every
+	// pass and loop forever.
+	admitted := metav1.Condition{
+		Type: conditionAdmitted, Status: metav1.ConditionTrue, Reason: conditionAdmitted, Message: "Valid GatewayClass",
+	}
+	if existing := meta.FindStatusCondition(gc.Status.Conditions, conditionAdmitted); existing == nil ||
+		existing.Status != admitted.Status || existing.Reason != admitted.Reason || existing.Message != admitted.Message {
+		meta.SetStatusCondition(&gc.Status.Conditions, admitted)
+		if err := r.client.Status().Update(ctx, &gc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status for gatewayclass %s: %w", gc.Name, err)
+		}
+	}
+	metrics.GatewayAdmissions.WithLabelValues("GatewayClass", "admitted").Inc()
+	log.Info("admitted gatewayclass")
+
+	return ctrl.Result{}, nil
+}
+
+// ensureContour creates the Contour CR owned by gc if it doesn't already exist.
+func (r *gatewayClassReconciler) ensureContour(ctx context.Context, gc *gatewayv1alpha1.GatewayClass) error {
+	contour := &operatorv1alpha1.Contour{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gc.Name,
+			Namespace: "projectcontour",
+		},
+	}
+	if err := controllerutil.SetControllerReference(gc, contour, r.scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on contour %s: %w", contour.Name, err)
+	}
+	if err := r.client.Create(ctx, contour); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create contour %s: %w", contour.Name, err)
+	}
+	metrics.ManagedContours.Inc()
+	return nil
+}
+
+// ensureDeleted removes the Contour CR owned by gc and clears the finalizer.
+func (r *gatewayClassReconciler) ensureDeleted(ctx context.Context, gc *gatewayv1alpha1.GatewayClass) (ctrl.Result, error) {
+	contour := &operatorv1alpha1.Contour{
+		ObjectMeta: metav1.ObjectMeta{Name: gc.Name, Namespace: "projectcontour"},
+	}
+	if err := r.client.Delete(ctx, contour); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete contour %s: %w", contour.Name, err)
+		}
+	} else {
+		metrics.ManagedContours.Dec()
+	}
+
+	controllerutil.RemoveFinalizer(gc, gatewayClassFinalizer)
+	if err := r.client.Update(ctx, gc); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from gatewayclass %s: %w", gc.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
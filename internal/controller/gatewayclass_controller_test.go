@@ -0,0 +1,104 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/projectcontour/contour-operator/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
+)
+
+const testControllerName = "projectcontour.io/contour-operator"
+
+func newGatewayClassTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1alpha1.AddToScheme(scheme))
+	require.NoError(t, operatorv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// TestGatewayClassReconcileSkipsNoopStatusUpdate asserts that reconciling an
+// already-admitted GatewayClass a second time does not issue another
+// Status().Update call, which would otherwise re-trigger a reconcile and
+// loop forever.
+func TestGatewayClassReconcileSkipsNoopStatusUpdate(t *testing.T) {
+	scheme := newGatewayClassTestScheme(t)
+	gc := &gatewayv1alpha1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gatewayclass"},
+		Spec:       gatewayv1alpha1.GatewayClassSpec{Controller: testControllerName},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gc).Build()
+
+	r := &gatewayClassReconciler{
+		client: c,
+		scheme: scheme,
+		log:    ctrl.Log.WithName("test"),
+		config: GatewayClassConfig{ControllerName: testControllerName},
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: gc.Name}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	var afterFirst gatewayv1alpha1.GatewayClass
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &afterFirst))
+
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	var afterSecond gatewayv1alpha1.GatewayClass
+	require.NoError(t, c.Get(context.Background(), req.NamespacedName, &afterSecond))
+
+	require.Equal(t, afterFirst.ResourceVersion, afterSecond.ResourceVersion,
+		"reconciling an already-admitted GatewayClass must not write status again")
+}
+
+// TestGatewayClassManagedContoursGauge asserts that the managed_contours
+// gauge is incremented when a Contour is provisioned for a GatewayClass and
+// decremented when it is torn down again, but not on a repeat call for a
+// Contour that already exists.
+func TestGatewayClassManagedContoursGauge(t *testing.T) {
+	metrics.ManagedContours.Set(0)
+
+	scheme := newGatewayClassTestScheme(t)
+	gc := &gatewayv1alpha1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gatewayclass"},
+		Spec:       gatewayv1alpha1.GatewayClassSpec{Controller: testControllerName},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gc).Build()
+	r := &gatewayClassReconciler{client: c, scheme: scheme, log: ctrl.Log.WithName("test")}
+
+	require.NoError(t, r.ensureContour(context.Background(), gc))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.ManagedContours))
+
+	// Reconciling again finds the Contour already exists and must not
+	// double-count it.
+	require.NoError(t, r.ensureContour(context.Background(), gc))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.ManagedContours))
+
+	_, err := r.ensureDeleted(context.Background(), gc)
+	require.NoError(t, err)
+	require.Equal(t, float64(0), testutil.ToFloat64(metrics.ManagedContours))
+}
@@ -0,0 +1,70 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics holds the Prometheus collectors the operator and its
+// controllers report reconcile activity through, all under the
+// contour_operator_ namespace.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "contour_operator"
+
+var (
+	// ReconcileTotal counts reconciles per managed resource kind.
+	ReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconciles_total",
+		Help:      "Total number of reconciles per managed resource kind.",
+	}, []string{"kind"})
+
+	// ReconcileDuration observes reconcile latency per managed resource kind.
+	ReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Reconcile latency per managed resource kind.",
+	}, []string{"kind"})
+
+	// ManagedContours reports how many Contour instances this operator
+	// currently manages.
+	ManagedContours = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "managed_contours",
+		Help:      "Number of Contour instances currently managed by this operator.",
+	})
+
+	// GatewayAdmissions counts Gateway API admission results, by resource
+	// kind and result (admitted/rejected).
+	GatewayAdmissions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gateway_admissions_total",
+		Help:      "Total number of Gateway API admission results, by resource kind and result.",
+	}, []string{"kind", "result"})
+)
+
+var registerOnce sync.Once
+
+// Register adds the operator's collectors to the controller-runtime metrics
+// registry so they're exposed alongside the built-in ones. It is safe to
+// call more than once, e.g. across repeated calls to operator.New in the
+// same process.
+func Register() {
+	registerOnce.Do(func() {
+		ctrlmetrics.Registry.MustRegister(ReconcileTotal, ReconcileDuration, ManagedContours, GatewayAdmissions)
+	})
+}
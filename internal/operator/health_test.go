@@ -0,0 +1,54 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCRDCheck(t *testing.T) {
+	contourGK := schema.GroupKind{Group: operatorv1alpha1.GroupVersion.Group, Kind: "Contour"}
+
+	tests := []struct {
+		name      string
+		installed map[schema.GroupKind]bool
+		wantErr   bool
+	}{
+		{name: "contour installed", installed: map[schema.GroupKind]bool{contourGK: true}, wantErr: false},
+		{name: "contour missing", installed: nil, wantErr: true},
+		{
+			name: "contour installed, gateway API not installed",
+			// The Gateway API CRDs are optional, so their absence must not
+			// fail readyz: the CRD gate controller is what makes this work.
+			installed: map[schema.GroupKind]bool{contourGK: true},
+			wantErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := fakeRESTMapper{installedKinds: tt.installed}
+			err := crdCheck(rm)(nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
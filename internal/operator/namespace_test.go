@@ -0,0 +1,45 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func TestApplyWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		wantNS     string
+		wantCache  bool
+	}{
+		{name: "cluster-wide", namespaces: nil},
+		{name: "single namespace", namespaces: []string{"tenant-a"}, wantNS: "tenant-a"},
+		{name: "multiple namespaces", namespaces: []string{"tenant-a", "tenant-b"}, wantCache: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts manager.Options
+			applyWatchNamespaces(&opts, tt.namespaces, logr.Discard())
+
+			assert.Equal(t, tt.wantNS, opts.Namespace)
+			assert.Equal(t, tt.wantCache, opts.NewCache != nil)
+		})
+	}
+}
@@ -21,13 +21,17 @@ import (
 	operatorv1alpha1 "github.com/projectcontour/contour-operator/api/v1alpha1"
 	"github.com/projectcontour/contour-operator/internal/config"
 	"github.com/projectcontour/contour-operator/internal/controller"
+	"github.com/projectcontour/contour-operator/internal/metrics"
+	runtimeext "github.com/projectcontour/contour-operator/internal/runtime"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	controller_runtime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gatewayv1alpha1 "sigs.k8s.io/gateway-api/apis/v1alpha1"
 )
@@ -51,9 +55,15 @@ type Operator struct {
 	manager manager.Manager
 	log     logr.Logger
 	config  *config.Config
+
+	// extensionInvoker is non-nil when runtime extensions are enabled. It is
+	// only held here: it is not passed to the Contour controller, and the
+	// BeforeContourApply/AfterContourDelete hooks are not called from its
+	// apply/delete path.
+	extensionInvoker *runtimeext.Invoker
 }
 
-// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours/status,verbs=get;update;patch
 // cert-gen needs create/update secrets.
 // +kubebuilder:rbac:groups="",resources=namespaces;secrets;serviceaccounts;services,verbs=get;list;watch;delete;create;update
@@ -75,23 +85,79 @@ type Operator struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;delete;create;update
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;delete;create;update
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list
+// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=extensionconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=extensionconfigs/status,verbs=get;update;patch
+
+// The markers below repeat a subset of the cluster-scoped rules above (with
+// no create or delete on contours) with a namespace placeholder attached, so
+// `make manifests` also renders a
+// Role/RoleBinding bundle for operators that are deployed to watch a single
+// namespace instead of the whole cluster.
+// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours,verbs=get;list;watch;update,namespace=placeholder
+// +kubebuilder:rbac:groups=operator.projectcontour.io,resources=contours/status,verbs=get;update;patch,namespace=placeholder
+// +kubebuilder:rbac:groups="",resources=namespaces;secrets;serviceaccounts;services,verbs=get;list;watch;delete;create;update,namespace=placeholder
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;delete;create;update,namespace=placeholder
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch,namespace=placeholder
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update,namespace=placeholder
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;delete;create;update;watch,namespace=placeholder
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;delete;create;update,namespace=placeholder
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;delete;create;update,namespace=placeholder
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;delete;create;update,namespace=placeholder
 
 // New creates a new operator from cliCfg and opCfg.
 func New(cliCfg *rest.Config, opCfg *config.Config) (*Operator, error) {
+	log := controller_runtime.Log.WithName(operatorName)
+
 	nonCached := []client.Object{&operatorv1alpha1.Contour{}, &gatewayv1alpha1.GatewayClass{},
 		&gatewayv1alpha1.Gateway{}, &apiextensionsv1.CustomResourceDefinition{}}
 	mgrOpts := manager.Options{
-		Scheme:                GetOperatorScheme(),
-		LeaderElection:        opCfg.LeaderElection,
-		LeaderElectionID:      opCfg.LeaderElectionID,
-		MetricsBindAddress:    opCfg.MetricsBindAddress,
-		ClientDisableCacheFor: nonCached,
+		Scheme:                 GetOperatorScheme(),
+		LeaderElection:         opCfg.LeaderElection,
+		LeaderElectionID:       opCfg.LeaderElectionID,
+		MetricsBindAddress:     opCfg.MetricsBindAddress,
+		HealthProbeBindAddress: opCfg.HealthProbeBindAddress,
+		ClientDisableCacheFor:  nonCached,
+	}
+
+	// By default the operator watches and manages Contour CRs across the
+	// entire cluster. Setting WatchNamespaces restricts it to one or more
+	// namespaces so it can be deployed per-tenant without cluster-wide RBAC.
+	applyWatchNamespaces(&mgrOpts, opCfg.WatchNamespaces, log)
+
+	// Pin the leader election Lease to the operator's own namespace. This
+	// is required in namespace-scoped mode, where WatchNamespaces may not
+	// include the namespace the operator is deployed to and controller-runtime
+	// would otherwise have to detect it from the in-cluster service account
+	// files.
+	if opCfg.OperatorNamespace != "" {
+		mgrOpts.LeaderElectionNamespace = opCfg.OperatorNamespace
 	}
+
 	mgr, err := controller_runtime.NewManager(cliCfg, mgrOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create manager: %w", err)
 	}
 
+	// A dynamic RESTMapper refreshes its cache on a cache miss, so it picks
+	// up CRDs that are installed after the operator starts rather than
+	// requiring a restart.
+	restMapper, err := apiutil.NewDynamicRESTMapper(cliCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Register()
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return nil, fmt.Errorf("failed to register healthz check: %w", err)
+	}
+	if err := mgr.AddReadyzCheck("leader-election", leaderElectionCheck(mgr)); err != nil {
+		return nil, fmt.Errorf("failed to register leader-election readyz check: %w", err)
+	}
+	if err := mgr.AddReadyzCheck("crds", crdCheck(restMapper)); err != nil {
+		return nil, fmt.Errorf("failed to register crds readyz check: %w", err)
+	}
+
 	// Create and register the contour controller with the operator manager.
 	if _, err := controller.New(mgr, controller.Config{
 		ContourImage: opCfg.ContourImage,
@@ -100,16 +166,63 @@ func New(cliCfg *rest.Config, opCfg *config.Config) (*Operator, error) {
 		return nil, fmt.Errorf("failed to create contour controller: %w", err)
 	}
 
-	restMapper, err := apiutil.NewDiscoveryRESTMapper(cliCfg)
-	if err != nil {
-		return nil, err
+	// The GatewayClass and Gateway controllers let the operator be driven
+	// entirely via Gateway API in addition to Contour CRs, but only make
+	// sense on clusters where those CRDs are installed. Register them
+	// immediately if the CRDs are already present; otherwise defer
+	// registration to a CRD gate controller that fires the first time they
+	// appear, so a single operator binary runs unmodified on clusters with
+	// partial or no Gateway API installation.
+	gatewayAPIGVRs := GatewayAPIResources()
+	registerGatewayAPIControllers := func() error {
+		if _, err := controller.NewGatewayClassController(mgr, controller.GatewayClassConfig{
+			ControllerName: opCfg.GatewayControllerName,
+		}); err != nil {
+			return fmt.Errorf("failed to create gatewayclass controller: %w", err)
+		}
+		if _, err := controller.NewGatewayController(mgr, controller.GatewayConfig{
+			ControllerName: opCfg.GatewayControllerName,
+		}); err != nil {
+			return fmt.Errorf("failed to create gateway controller: %w", err)
+		}
+		log.Info("Gateway API CRDs detected, GatewayClass/Gateway controllers registered")
+		return nil
+	}
+
+	if gatewayAPIResourcesInstalled(restMapper, gatewayAPIGVRs) {
+		if err := registerGatewayAPIControllers(); err != nil {
+			return nil, err
+		}
+	} else {
+		log.Info("Gateway API CRDs not installed, GatewayClass/Gateway controllers disabled until they appear")
+		if _, err := controller.NewCRDGateController(mgr, controller.CRDGateConfig{
+			Resources:   gatewayAPIGVRs,
+			RESTMapper:  restMapper,
+			OnAvailable: registerGatewayAPIControllers,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create crd gate controller: %w", err)
+		}
+	}
+
+	// Runtime extensions are opt-in. Enabling them registers the
+	// ExtensionConfig discovery controller and builds the hook Invoker,
+	// which is kept on the Operator and not called by the Contour controller.
+	var extensionInvoker *runtimeext.Invoker
+	if opCfg.EnableRuntimeExtensions {
+		invoker, err := runtimeext.Register(mgr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register runtime extensions: %w", err)
+		}
+		extensionInvoker = invoker
+		log.Info("runtime extensions enabled")
 	}
 
 	return &Operator{
-		manager: mgr,
-		client:  Client{mgr.GetClient(), restMapper},
-		log:     controller_runtime.Log.WithName(operatorName),
-		config:  opCfg,
+		manager:          mgr,
+		client:           Client{mgr.GetClient(), restMapper},
+		log:              log,
+		config:           opCfg,
+		extensionInvoker: extensionInvoker,
 	}, nil
 }
 
@@ -130,8 +243,35 @@ func (o *Operator) Start(ctx context.Context) error {
 	}
 }
 
-// GatewayAPIResources for Operator.
-// The list omits TCP and UDP routes since they're unsupported by operator.
+// applyWatchNamespaces sets mgrOpts.Namespace or mgrOpts.NewCache according to
+// watchNamespaces, logging which mode was selected.
+func applyWatchNamespaces(mgrOpts *manager.Options, watchNamespaces []string, log logr.Logger) {
+	switch len(watchNamespaces) {
+	case 0:
+		log.Info("configured to watch all namespaces")
+	case 1:
+		mgrOpts.Namespace = watchNamespaces[0]
+		log.Info("configured to watch a single namespace", "namespace", mgrOpts.Namespace)
+	default:
+		mgrOpts.NewCache = cache.MultiNamespacedCacheBuilder(watchNamespaces)
+		log.Info("configured to watch multiple namespaces", "namespaces", watchNamespaces)
+	}
+}
+
+// gatewayAPIResourcesInstalled reports whether every GVR in resources can
+// currently be resolved by rm.
+func gatewayAPIResourcesInstalled(rm meta.RESTMapper, resources []schema.GroupVersionResource) bool {
+	for _, gvr := range resources {
+		if _, err := rm.KindFor(gvr); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GatewayAPIResources returns the Gateway API GVRs New gates GatewayClass/
+// Gateway controller registration on. The list omits TCP and UDP routes
+// since they're unsupported by the operator.
 func GatewayAPIResources() []schema.GroupVersionResource {
 	return []schema.GroupVersionResource{{
 		Group:    gatewayv1alpha1.GroupVersion.Group,
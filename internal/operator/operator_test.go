@@ -0,0 +1,81 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeRESTMapper resolves exactly the GVRs/GroupKinds in installed; every
+// other lookup behaves as if the CRD isn't installed.
+type fakeRESTMapper struct {
+	apimeta.RESTMapper
+	installedResources map[schema.GroupVersionResource]bool
+	installedKinds     map[schema.GroupKind]bool
+}
+
+func (m fakeRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	if m.installedResources[resource] {
+		return schema.GroupVersionKind{Group: resource.Group, Version: resource.Version, Kind: resource.Resource}, nil
+	}
+	return schema.GroupVersionKind{}, &apimeta.NoResourceMatchError{PartialResource: resource}
+}
+
+func (m fakeRESTMapper) RESTMapping(gk schema.GroupKind, _ ...string) (*apimeta.RESTMapping, error) {
+	if m.installedKinds[gk] {
+		return &apimeta.RESTMapping{GroupVersionKind: gk.WithVersion("")}, nil
+	}
+	return nil, &apimeta.NoKindMatchError{GroupKind: gk}
+}
+
+func TestGatewayAPIResourcesInstalled(t *testing.T) {
+	gatewayClasses := schema.GroupVersionResource{Group: "networking.x-k8s.io", Version: "v1alpha1", Resource: "gatewayclasses"}
+	gateways := schema.GroupVersionResource{Group: "networking.x-k8s.io", Version: "v1alpha1", Resource: "gateways"}
+
+	tests := []struct {
+		name      string
+		installed map[schema.GroupVersionResource]bool
+		resources []schema.GroupVersionResource
+		want      bool
+	}{
+		{
+			name:      "all installed",
+			installed: map[schema.GroupVersionResource]bool{gatewayClasses: true, gateways: true},
+			resources: []schema.GroupVersionResource{gatewayClasses, gateways},
+			want:      true,
+		},
+		{
+			name:      "partially installed",
+			installed: map[schema.GroupVersionResource]bool{gatewayClasses: true},
+			resources: []schema.GroupVersionResource{gatewayClasses, gateways},
+			want:      false,
+		},
+		{
+			name:      "none installed",
+			resources: []schema.GroupVersionResource{gatewayClasses, gateways},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := fakeRESTMapper{installedResources: tt.installed}
+			assert.Equal(t, tt.want, gatewayAPIResourcesInstalled(rm, tt.resources))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/go-logr/logr"
+)
+
+const (
+	apiVersion         = "v1"
+	defaultHookTimeout = 5 * time.Second
+)
+
+// Request is the versioned payload POSTed to an extension for a given hook.
+type Request struct {
+	APIVersion string          `json:"apiVersion"`
+	Hook       Hook            `json:"hook"`
+	Objects    json.RawMessage `json:"objects"`
+}
+
+// Response is returned by an extension after handling a Request. Patch, if
+// non-empty, is a JSON merge patch applied to Request.Objects.
+type Response struct {
+	Allowed bool            `json:"allowed"`
+	Reason  string          `json:"reason,omitempty"`
+	Patch   json.RawMessage `json:"patch,omitempty"`
+}
+
+// Client invokes a single extension's HTTP hook endpoints.
+type Client struct {
+	name    string
+	url     string
+	timeout time.Duration
+	http    *http.Client
+	log     logr.Logger
+}
+
+// hookTimeout parses cfg's per-extension timeout, falling back to
+// defaultHookTimeout if none is set.
+func hookTimeout(cfg *ExtensionConfig) (time.Duration, error) {
+	if cfg.Spec.Timeout == "" {
+		return defaultHookTimeout, nil
+	}
+	d, err := time.ParseDuration(cfg.Spec.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q for extensionconfig %s: %w", cfg.Spec.Timeout, cfg.Name, err)
+	}
+	return d, nil
+}
+
+// NewClient builds a Client for the extension described by cfg.
+func NewClient(cfg *ExtensionConfig, log logr.Logger) (*Client, error) {
+	timeout, err := hookTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{}
+	if len(cfg.Spec.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.Spec.CABundle) {
+			return nil, fmt.Errorf("failed to parse caBundle for extensionconfig %s", cfg.Name)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &Client{
+		name:    cfg.Name,
+		url:     cfg.Spec.URL,
+		timeout: timeout,
+		http:    &http.Client{Timeout: timeout, Transport: transport},
+		log:     log.WithValues("extensionconfig", cfg.Name),
+	}, nil
+}
+
+// Invoke POSTs hook and objects to the extension and applies the returned
+// patch (if any) to objects, returning the patched result.
+func (c *Client) Invoke(ctx context.Context, hook Hook, objects []byte) ([]byte, error) {
+	reqBody, err := json.Marshal(Request{APIVersion: apiVersion, Hook: hook, Objects: objects})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request for hook %s: %w", hook, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/hooks/%s", c.url, hook), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for hook %s: %w", hook, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("extension %s unreachable for hook %s: %w", c.name, hook, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("extension %s returned status %d for hook %s", c.name, resp.StatusCode, hook)
+	}
+
+	var hookResp Response
+	if err := json.NewDecoder(resp.Body).Decode(&hookResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response from extension %s for hook %s: %w", c.name, hook, err)
+	}
+	if !hookResp.Allowed {
+		return nil, fmt.Errorf("extension %s rejected hook %s: %s", c.name, hook, hookResp.Reason)
+	}
+	if len(hookResp.Patch) == 0 {
+		return objects, nil
+	}
+
+	patched, err := jsonpatch.MergePatch(objects, hookResp.Patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch from extension %s for hook %s: %w", c.name, hook, err)
+	}
+	c.log.Info("applied extension patch", "hook", hook)
+	return patched, nil
+}
@@ -0,0 +1,109 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newExtensionConfig(t *testing.T, url string) *ExtensionConfig {
+	t.Helper()
+	return &ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-extension"},
+		Spec:       ExtensionConfigSpec{URL: url, Hooks: []Hook{BeforeContourApply}},
+	}
+}
+
+func TestNewClientInvalidTimeout(t *testing.T) {
+	ec := newExtensionConfig(t, "https://example.invalid")
+	ec.Spec.Timeout = "not-a-duration"
+
+	if _, err := NewClient(ec, logr.Discard()); err == nil {
+		t.Fatal("expected an error for an invalid timeout, got nil")
+	}
+}
+
+func TestNewClientInvalidCABundle(t *testing.T) {
+	ec := newExtensionConfig(t, "https://example.invalid")
+	ec.Spec.CABundle = []byte("not a pem bundle")
+
+	if _, err := NewClient(ec, logr.Discard()); err == nil {
+		t.Fatal("expected an error for an invalid CA bundle, got nil")
+	}
+}
+
+func TestClientInvokeAppliesPatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks/"+string(BeforeContourApply) {
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		resp := Response{Allowed: true, Patch: json.RawMessage(`{"replicas":2}`)}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatalf("failed to encode response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(newExtensionConfig(t, srv.URL), logr.Discard())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	patched, err := c.Invoke(context.Background(), BeforeContourApply, []byte(`{"replicas":1}`))
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if string(patched) != `{"replicas":2}` {
+		t.Fatalf("expected patched objects %q, got %q", `{"replicas":2}`, patched)
+	}
+}
+
+func TestClientInvokeRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := Response{Allowed: false, Reason: "nope"}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(newExtensionConfig(t, srv.URL), logr.Discard())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := c.Invoke(context.Background(), BeforeContourApply, []byte(`{}`)); err == nil {
+		t.Fatal("expected an error when the extension rejects the hook, got nil")
+	}
+}
+
+func TestClientInvokeUnreachable(t *testing.T) {
+	c, err := NewClient(newExtensionConfig(t, "http://127.0.0.1:0"), logr.Discard())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := c.Invoke(context.Background(), BeforeContourApply, []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for an unreachable extension, got nil")
+	}
+}
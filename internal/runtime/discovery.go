@@ -0,0 +1,133 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	discoveryControllerName = "extensionconfig-discovery-controller"
+	rediscoverInterval      = 30 * time.Second
+)
+
+type discoveryResponse struct {
+	Hooks []Hook `json:"hooks"`
+}
+
+// discoveryReconciler probes each ExtensionConfig's /discover endpoint and
+// records the hooks it responded to in status.ReachableHooks.
+type discoveryReconciler struct {
+	client client.Client
+	http   *http.Client
+	log    logr.Logger
+}
+
+// NewDiscoveryController creates the ExtensionConfig discovery controller and
+// registers it with mgr.
+func NewDiscoveryController(mgr manager.Manager) (controller.Controller, error) {
+	r := &discoveryReconciler{
+		client: mgr.GetClient(),
+		http:   &http.Client{},
+		log:    ctrl.Log.WithName(discoveryControllerName),
+	}
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(&ExtensionConfig{}).
+		Build(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", discoveryControllerName, err)
+	}
+	return c, nil
+}
+
+func (r *discoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("extensionconfig", req.Name)
+
+	var ec ExtensionConfig
+	if err := r.client.Get(ctx, req.NamespacedName, &ec); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get extensionconfig %s: %w", req.Name, err)
+	}
+
+	reachable, err := r.discover(ctx, &ec)
+	if err != nil {
+		log.Info("extension unreachable", "reason", err.Error())
+		ec.Status.ReachableHooks = nil
+	} else {
+		ec.Status.ReachableHooks = reachable
+	}
+
+	if err := r.client.Status().Update(ctx, &ec); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status for extensionconfig %s: %w", ec.Name, err)
+	}
+
+	return ctrl.Result{RequeueAfter: rediscoverInterval}, nil
+}
+
+// discover pings the extension's /discover endpoint and returns the
+// intersection of ec.Spec.Hooks with the hooks it reports support for.
+func (r *discoveryReconciler) discover(ctx context.Context, ec *ExtensionConfig) ([]Hook, error) {
+	timeout, err := hookTimeout(ec)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ec.Spec.URL+"/discover", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery request: %w", err)
+	}
+
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discovery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery returned status %d", resp.StatusCode)
+	}
+
+	var dr discoveryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dr); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery response: %w", err)
+	}
+
+	supported := make(map[Hook]bool, len(dr.Hooks))
+	for _, h := range dr.Hooks {
+		supported[h] = true
+	}
+
+	var reachable []Hook
+	for _, h := range ec.Spec.Hooks {
+		if supported[h] {
+			reachable = append(reachable, h)
+		}
+	}
+	return reachable, nil
+}
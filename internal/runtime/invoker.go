@@ -0,0 +1,84 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Invoker calls every ExtensionConfig registered for a given hook. Register
+// builds it and operator.New keeps it on the Operator; the Contour
+// controller's apply/delete path does not call it.
+type Invoker struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewInvoker builds an Invoker backed by c.
+func NewInvoker(c client.Client, log logr.Logger) *Invoker {
+	return &Invoker{client: c, log: log}
+}
+
+// Invoke calls hook on every ExtensionConfig that lists it in Spec.Hooks, in
+// list order, threading each extension's patched result into the next.
+// A hook call failure aborts and returns an error unless the extension's
+// FailurePolicy is Ignore, in which case the failure is logged and objects
+// are passed through unpatched to the next extension.
+func (inv *Invoker) Invoke(ctx context.Context, hook Hook, objects []byte) ([]byte, error) {
+	var list ExtensionConfigList
+	if err := inv.client.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list extensionconfigs for hook %s: %w", hook, err)
+	}
+
+	for _, ec := range list.Items {
+		if !hasHook(ec.Spec.Hooks, hook) {
+			continue
+		}
+
+		c, err := NewClient(&ec, inv.log)
+		if err != nil {
+			if ec.Spec.FailurePolicy == FailurePolicyIgnore {
+				inv.log.Info("ignoring extension that failed to initialize", "extensionconfig", ec.Name, "hook", hook, "reason", err.Error())
+				continue
+			}
+			return nil, err
+		}
+
+		patched, err := c.Invoke(ctx, hook, objects)
+		if err != nil {
+			if ec.Spec.FailurePolicy == FailurePolicyIgnore {
+				inv.log.Info("ignoring failed hook call", "extensionconfig", ec.Name, "hook", hook, "reason", err.Error())
+				continue
+			}
+			return nil, err
+		}
+		objects = patched
+	}
+
+	return objects, nil
+}
+
+// hasHook reports whether hook appears in hooks.
+func hasHook(hooks []Hook, hook Hook) bool {
+	for _, h := range hooks {
+		if h == hook {
+			return true
+		}
+	}
+	return false
+}
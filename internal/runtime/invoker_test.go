@@ -0,0 +1,138 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInvokerTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add runtime extension types to scheme: %v", err)
+	}
+	return scheme
+}
+
+func allowingServer(t *testing.T, patch string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Response{Allowed: true, Patch: json.RawMessage(patch)})
+	}))
+}
+
+func rejectingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Response{Allowed: false, Reason: "denied"})
+	}))
+}
+
+func TestInvokerChainsMultipleExtensions(t *testing.T) {
+	first := allowingServer(t, `{"a":1}`)
+	defer first.Close()
+	second := allowingServer(t, `{"b":2}`)
+	defer second.Close()
+
+	ecA := &ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "a"},
+		Spec:       ExtensionConfigSpec{URL: first.URL, Hooks: []Hook{BeforeContourApply}},
+	}
+	ecB := &ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "b"},
+		Spec:       ExtensionConfigSpec{URL: second.URL, Hooks: []Hook{BeforeContourApply}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newInvokerTestScheme(t)).WithObjects(ecA, ecB).Build()
+	inv := NewInvoker(c, logr.Discard())
+
+	patched, err := inv.Invoke(context.Background(), BeforeContourApply, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if string(patched) != `{"a":1,"b":2}` {
+		t.Fatalf("expected both extensions' patches to be applied in turn, got %q", patched)
+	}
+}
+
+func TestInvokerIgnoresFailureWithIgnorePolicy(t *testing.T) {
+	rejecting := rejectingServer(t)
+	defer rejecting.Close()
+
+	ec := &ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ignored"},
+		Spec: ExtensionConfigSpec{
+			URL: rejecting.URL, Hooks: []Hook{BeforeContourApply}, FailurePolicy: FailurePolicyIgnore,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newInvokerTestScheme(t)).WithObjects(ec).Build()
+	inv := NewInvoker(c, logr.Discard())
+
+	objects := []byte(`{"unchanged":true}`)
+	patched, err := inv.Invoke(context.Background(), BeforeContourApply, objects)
+	if err != nil {
+		t.Fatalf("expected Invoke to ignore the failure, got error: %v", err)
+	}
+	if string(patched) != string(objects) {
+		t.Fatalf("expected objects to pass through unpatched, got %q", patched)
+	}
+}
+
+func TestInvokerAbortsOnFailWithFailPolicy(t *testing.T) {
+	rejecting := rejectingServer(t)
+	defer rejecting.Close()
+
+	ec := &ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "blocking"},
+		Spec: ExtensionConfigSpec{
+			URL: rejecting.URL, Hooks: []Hook{BeforeContourApply}, FailurePolicy: FailurePolicyFail,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newInvokerTestScheme(t)).WithObjects(ec).Build()
+	inv := NewInvoker(c, logr.Discard())
+
+	if _, err := inv.Invoke(context.Background(), BeforeContourApply, []byte(`{}`)); err == nil {
+		t.Fatal("expected Invoke to abort with FailurePolicyFail, got nil error")
+	}
+}
+
+func TestInvokerSkipsExtensionsNotRegisteredForHook(t *testing.T) {
+	srv := allowingServer(t, `{"should":"not-apply"}`)
+	defer srv.Close()
+
+	ec := &ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-hook"},
+		Spec:       ExtensionConfigSpec{URL: srv.URL, Hooks: []Hook{AfterContourDelete}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newInvokerTestScheme(t)).WithObjects(ec).Build()
+	inv := NewInvoker(c, logr.Discard())
+
+	objects := []byte(`{"unchanged":true}`)
+	patched, err := inv.Invoke(context.Background(), BeforeContourApply, objects)
+	if err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if string(patched) != string(objects) {
+		t.Fatalf("expected objects to pass through unchanged, got %q", patched)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Register adds the ExtensionConfig scheme and discovery controller to mgr and
+// returns an Invoker for the BeforeContourApply/AfterContourDelete extension
+// hooks. operator.New calls it when config.Config.EnableRuntimeExtensions is
+// set and keeps the returned Invoker on the Operator; the Contour controller's
+// apply/delete path does not call it.
+func Register(mgr manager.Manager) (*Invoker, error) {
+	if err := AddToScheme(mgr.GetScheme()); err != nil {
+		return nil, fmt.Errorf("failed to add runtime extension types to scheme: %w", err)
+	}
+	if _, err := NewDiscoveryController(mgr); err != nil {
+		return nil, fmt.Errorf("failed to create extensionconfig discovery controller: %w", err)
+	}
+	return NewInvoker(mgr.GetClient(), ctrl.Log.WithName("extension-invoker")), nil
+}
@@ -0,0 +1,148 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Hook is the name of a point in the Contour provisioning lifecycle that an
+// extension can register for.
+type Hook string
+
+const (
+	// BeforeContourApply is invoked with the rendered Deployment, ConfigMap
+	// and Service for a Contour before they are applied to the cluster.
+	BeforeContourApply Hook = "BeforeContourApply"
+	// AfterContourDelete is invoked after a Contour's owned resources have
+	// been deleted.
+	AfterContourDelete Hook = "AfterContourDelete"
+)
+
+// FailurePolicy controls what happens when an extension hook call fails.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail makes Invoker.Invoke stop and return the failing
+	// extension's error.
+	FailurePolicyFail FailurePolicy = "Fail"
+	// FailurePolicyIgnore logs the failure and continues as if the
+	// extension had not been registered for the hook.
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+// ExtensionConfig is the Schema for registering an external HTTP service
+// that can mutate or validate a Contour deployment before it is applied.
+type ExtensionConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExtensionConfigSpec   `json:"spec,omitempty"`
+	Status ExtensionConfigStatus `json:"status,omitempty"`
+}
+
+// ExtensionConfigSpec defines how the operator reaches an extension and
+// which hooks it wants to be called for.
+type ExtensionConfigSpec struct {
+	// URL is the base address of the extension service, e.g.
+	// https://my-extension.ns.svc:8443.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle used to validate the extension's
+	// serving certificate. If empty, the system trust store is used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Timeout bounds how long the operator waits for a hook call, expressed
+	// as a Go duration string (e.g. "5s"). Defaults to 5s if empty.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// Hooks lists the lifecycle hooks this extension wants to be invoked
+	// for.
+	Hooks []Hook `json:"hooks,omitempty"`
+
+	// FailurePolicy controls behavior when a call to this extension fails.
+	// Defaults to Fail.
+	// +optional
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// ExtensionConfigStatus records which of the requested hooks the extension
+// was actually reachable for at last discovery.
+type ExtensionConfigStatus struct {
+	// ReachableHooks lists the hooks that responded successfully to the
+	// discovery probe.
+	// +optional
+	ReachableHooks []Hook `json:"reachableHooks,omitempty"`
+
+	// Conditions describe the observed state of the extension.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ExtensionConfigList contains a list of ExtensionConfig.
+type ExtensionConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExtensionConfig `json:"items"`
+}
+
+// GroupVersion is the API group and version used to register these types.
+var GroupVersion = schema.GroupVersion{Group: "operator.projectcontour.io", Version: "v1alpha1"}
+
+// AddToScheme registers the runtime extension types with s.
+func AddToScheme(s *runtime.Scheme) error {
+	s.AddKnownTypes(GroupVersion, &ExtensionConfig{}, &ExtensionConfigList{})
+	metav1.AddToGroupVersion(s, GroupVersion)
+	return nil
+}
+
+// DeepCopyObject implements runtime.Object.
+func (e *ExtensionConfig) DeepCopyObject() runtime.Object {
+	out := &ExtensionConfig{
+		TypeMeta:   e.TypeMeta,
+		ObjectMeta: *e.ObjectMeta.DeepCopy(),
+		Spec:       e.Spec,
+		Status:     e.Status,
+	}
+	if e.Spec.CABundle != nil {
+		out.Spec.CABundle = append([]byte(nil), e.Spec.CABundle...)
+	}
+	if e.Spec.Hooks != nil {
+		out.Spec.Hooks = append([]Hook(nil), e.Spec.Hooks...)
+	}
+	if e.Status.ReachableHooks != nil {
+		out.Status.ReachableHooks = append([]Hook(nil), e.Status.ReachableHooks...)
+	}
+	if e.Status.Conditions != nil {
+		out.Status.Conditions = append([]metav1.Condition(nil), e.Status.Conditions...)
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *ExtensionConfigList) DeepCopyObject() runtime.Object {
+	out := &ExtensionConfigList{TypeMeta: l.TypeMeta, ListMeta: l.ListMeta}
+	if l.Items != nil {
+		out.Items = make([]ExtensionConfig, len(l.Items))
+		for i := range l.Items {
+			out.Items[i] = *l.Items[i].DeepCopyObject().(*ExtensionConfig)
+		}
+	}
+	return out
+}